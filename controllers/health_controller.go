@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jmoiron/sqlx"
+)
+
+// HealthController exposes liveness and readiness probes for orchestrators.
+type HealthController struct {
+	db *sqlx.DB
+}
+
+// NewHealthController creates a HealthController backed by db for readiness checks.
+func NewHealthController(db *sqlx.DB) *HealthController {
+	return &HealthController{db: db}
+}
+
+// Live reports that the process is up and able to serve requests.
+func (hc *HealthController) Live(c *gin.Context) {
+	c.Status(http.StatusOK)
+}
+
+// Ready reports whether the service can currently reach its database.
+func (hc *HealthController) Ready(c *gin.Context) {
+	if err := hc.db.PingContext(c.Request.Context()); err != nil {
+		c.Status(http.StatusServiceUnavailable)
+		return
+	}
+	c.Status(http.StatusOK)
+}
@@ -0,0 +1,279 @@
+// Package controllers содержит HTTP-обработчики Gin, связывающие запросы с
+// репозиторием песен.
+package controllers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NickLand74/Mobile-library-api/enricher"
+	"github.com/NickLand74/Mobile-library-api/middleware"
+	"github.com/NickLand74/Mobile-library-api/models"
+	"github.com/NickLand74/Mobile-library-api/repository"
+)
+
+// SongController обрабатывает HTTP-запросы к /songs, делегируя доступ к
+// данным SongRepository и постановку фоновых задач обогащения Enricher.
+type SongController struct {
+	repo     repository.SongRepository
+	enricher *enricher.Enricher
+}
+
+// NewSongController создаёт SongController поверх переданного репозитория и
+// воркера обогащения.
+func NewSongController(repo repository.SongRepository, songEnricher *enricher.Enricher) *SongController {
+	return &SongController{repo: repo, enricher: songEnricher}
+}
+
+// @Summary Get Songs
+// @Description Retrieve a paginated, filterable, sortable list of songs
+// @Param limit query int false "Limit the number of songs" default(10)
+// @Param page query int false "Page number" default(1)
+// @Param title query string false "Filter by title (substring match)"
+// @Param artist query string false "Filter by artist (substring match)"
+// @Param group query string false "Filter by group name"
+// @Param releaseDateFrom query string false "Only songs released on or after this date"
+// @Param releaseDateTo query string false "Only songs released on or before this date"
+// @Param q query string false "Full-text search over title, artist and text"
+// @Param sort query string false "Comma-separated field:direction pairs, e.g. releaseDate:desc,title:asc"
+// @Produce json
+// @Success 200 {object} models.SongListResponse
+// @Failure 400 {object} models.ErrorResponse "Ошибка в запросе"
+// @Failure 500 {object} models.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /songs [get]
+func (sc *SongController) GetSongs(c *gin.Context) {
+	var pagination models.Pagination
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	var filter models.SongFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if pagination.Page <= 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+
+	songs, total, err := sc.repo.List(c.Request.Context(), filter, pagination)
+	if err != nil {
+		slog.Error("failed to list songs", "request_id", middleware.RequestIDFromContext(c), "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Internal Server Error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SongListResponse{
+		Data:  songs,
+		Total: total,
+		Page:  pagination.Page,
+		Limit: pagination.Limit,
+	})
+}
+
+// @Summary Получить текст песни
+// @Description Возвращает текст песни по ID с поддержкой пагинации
+// @Accept json
+// @Produce json
+// @Param id path string true "ID песни"
+// @Param page query int false "Номер страницы" default(1)
+// @Param limit query int false "Количество куплетов на странице" default(10)
+// @Success 200 {array} string "Успешный ответ"
+// @Failure 400 {object} models.ErrorResponse "Ошибка в запросе"
+// @Failure 404 {object} models.ErrorResponse "Песня не найдена"
+// @Router /songs/{id}/text [get]
+func (sc *SongController) GetSongText(c *gin.Context) {
+	id := c.Param("id")
+
+	var pagination models.Pagination
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	songID, err := strconv.Atoi(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid song id"})
+		return
+	}
+
+	if pagination.Page <= 0 {
+		pagination.Page = 1
+	}
+	if pagination.Limit <= 0 {
+		pagination.Limit = 10
+	}
+
+	text, err := sc.repo.GetText(c.Request.Context(), songID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Song not found"})
+		return
+	}
+
+	if text == "" {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "No text found for this song"})
+		return
+	}
+
+	verses := strings.Split(text, "\n\n")
+	start := (pagination.Page - 1) * pagination.Limit
+	end := start + pagination.Limit
+
+	if start >= len(verses) {
+		c.JSON(http.StatusOK, []string{})
+		return
+	}
+
+	if end > len(verses) {
+		end = len(verses)
+	}
+
+	c.JSON(http.StatusOK, verses[start:end])
+}
+
+// @Summary Удалить песню
+// @Description Удаляет песню по ID
+// @Accept json
+// @Produce json
+// @Param id path string true "ID песни"
+// @Success 200 {object} models.SuccessResponse "Успешный ответ"
+// @Failure 404 {object} models.ErrorResponse "Песня не найдена"
+// @Failure 500 {object} models.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /songs/{id} [delete]
+func (sc *SongController) DeleteSong(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid song id"})
+		return
+	}
+
+	if err := sc.repo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Song not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Message: "Song deleted successfully"})
+}
+
+// @Summary Обновить информацию о песне
+// @Description Обновляет данные песни по ID
+// @Accept json
+// @Produce json
+// @Param id path string true "ID песни"
+// @Param song body models.SongUpdateRequest true "Данные для обновления песни"
+// @Success 200 {object} models.SuccessResponse "Успешный ответ"
+// @Failure 400 {object} models.ErrorResponse "Ошибка в запросе"
+// @Failure 404 {object} models.ErrorResponse "Песня не найдена"
+// @Failure 500 {object} models.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /songs/{id} [put]
+func (sc *SongController) UpdateSong(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid song id"})
+		return
+	}
+
+	// PUT replaces the full row, so unlike Song (used by Create), every field
+	// here is required — a partial body must not silently zero out data that
+	// enrichment already filled in.
+	var req models.SongUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	song := models.Song{
+		Title:       req.Title,
+		Artist:      req.Artist,
+		ReleaseDate: req.ReleaseDate,
+		Text:        req.Text,
+		Link:        req.Link,
+	}
+
+	if err := sc.repo.Update(c.Request.Context(), id, song); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Song not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Internal server error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse{Message: "Song updated successfully"})
+}
+
+// @Summary Создать новую песню
+// @Description Создает новую песню в базе данных. Если releaseDate, text или
+// link не переданы, они будут дозаполнены в фоне через Music Info API, а
+// текущий статус можно отследить через GET /songs/{id}/status.
+// @Accept json
+// @Produce json
+// @Param song body models.Song true "Данные для новой песни"
+// @Success 201 {object} models.Song "Успешный ответ с созданной песней"
+// @Failure 400 {object} models.ErrorResponse "Ошибка в запросе"
+// @Failure 500 {object} models.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /songs [post]
+func (sc *SongController) CreateSong(c *gin.Context) {
+	var song models.Song
+	if err := c.ShouldBindJSON(&song); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	needsEnrichment := song.ReleaseDate == "" || song.Text == "" || song.Link == ""
+	song.EnrichmentStatus = string(enricher.StatusEnriched)
+	if needsEnrichment {
+		song.EnrichmentStatus = string(enricher.StatusPending)
+	}
+
+	created, err := sc.repo.Create(c.Request.Context(), song)
+	if err != nil {
+		slog.Error("failed to create song", "request_id", middleware.RequestIDFromContext(c), "error", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if needsEnrichment {
+		sc.enricher.Enqueue(enricher.Job{SongID: created.ID, Artist: created.Artist, Title: created.Title})
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// @Summary Статус обогащения песни
+// @Description Возвращает текущий статус фонового обогащения метаданными песни: pending, enriched или failed
+// @Produce json
+// @Param id path string true "ID песни"
+// @Success 200 {object} models.EnrichmentStatusResponse "Успешный ответ"
+// @Failure 404 {object} models.ErrorResponse "Песня не найдена"
+// @Failure 500 {object} models.ErrorResponse "Внутренняя ошибка сервера"
+// @Router /songs/{id}/status [get]
+func (sc *SongController) GetSongStatus(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "invalid song id"})
+		return
+	}
+
+	status, err := sc.repo.GetStatus(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Song not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.EnrichmentStatusResponse{Status: status})
+}
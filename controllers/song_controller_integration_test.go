@@ -0,0 +1,70 @@
+//go:build integration
+
+package controllers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/joho/godotenv"
+
+	"github.com/NickLand74/Mobile-library-api/controllers"
+	appdb "github.com/NickLand74/Mobile-library-api/db"
+	"github.com/NickLand74/Mobile-library-api/enricher"
+	"github.com/NickLand74/Mobile-library-api/repository"
+	"github.com/NickLand74/Mobile-library-api/routes"
+)
+
+// setupIntegrationRouter wires the real Postgres-backed repository and
+// enricher the way main.go does (with a smaller enricher queue and pool, and
+// without main.go's middleware, health and metrics routes), so these tests
+// exercise the song routes end to end against a real database.
+func setupIntegrationRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	if err := godotenv.Load("../.env"); err != nil {
+		t.Fatalf("Error loading .env file: %v", err)
+	}
+
+	database, err := appdb.EnsureDB(appdb.ConfigFromEnv())
+	if err != nil {
+		t.Fatalf("Error connecting to database: %v", err)
+	}
+
+	songEnricher := enricher.New(os.Getenv("MUSIC_INFO_API_URL"), 10, 5)
+	songEnricher.Start(database, 1)
+
+	songRepo := repository.NewSongRepository(database)
+	songController := controllers.NewSongController(songRepo, songEnricher)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	routes.Register(r, songController)
+	return r
+}
+
+func TestIntegrationCreateAndFetchSong(t *testing.T) {
+	r := setupIntegrationRouter(t)
+
+	jsonStr := []byte(`{"title":"Test Song", "artist":"Test Artist", "releaseDate":"2022-01-01", "text":"Test lyrics", "link":"test.com"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/songs", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, w.Code)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/songs?page=1&limit=10", nil)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/NickLand74/Mobile-library-api/enricher"
+	"github.com/NickLand74/Mobile-library-api/models"
+	"github.com/NickLand74/Mobile-library-api/repository"
+)
+
+// mockSongRepository реализует repository.SongRepository в памяти, чтобы
+// тестировать контроллер без подключения к Postgres.
+type mockSongRepository struct {
+	songs  map[int]models.Song
+	nextID int
+}
+
+func newMockSongRepository(songs ...models.Song) *mockSongRepository {
+	repo := &mockSongRepository{songs: make(map[int]models.Song)}
+	for _, song := range songs {
+		repo.songs[song.ID] = song
+		if song.ID >= repo.nextID {
+			repo.nextID = song.ID + 1
+		}
+	}
+	return repo
+}
+
+func (m *mockSongRepository) List(ctx context.Context, filter models.SongFilter, pagination models.Pagination) ([]models.Song, int, error) {
+	var songs []models.Song
+	for _, song := range m.songs {
+		songs = append(songs, song)
+	}
+	return songs, len(songs), nil
+}
+
+func (m *mockSongRepository) Get(ctx context.Context, id int) (models.Song, error) {
+	song, ok := m.songs[id]
+	if !ok {
+		return models.Song{}, repository.ErrNotFound
+	}
+	return song, nil
+}
+
+func (m *mockSongRepository) GetText(ctx context.Context, id int) (string, error) {
+	song, ok := m.songs[id]
+	if !ok {
+		return "", repository.ErrNotFound
+	}
+	return song.Text, nil
+}
+
+func (m *mockSongRepository) GetStatus(ctx context.Context, id int) (string, error) {
+	song, ok := m.songs[id]
+	if !ok {
+		return "", repository.ErrNotFound
+	}
+	return song.EnrichmentStatus, nil
+}
+
+func (m *mockSongRepository) Create(ctx context.Context, song models.Song) (models.Song, error) {
+	song.ID = m.nextID
+	m.nextID++
+	m.songs[song.ID] = song
+	return song, nil
+}
+
+func (m *mockSongRepository) Update(ctx context.Context, id int, song models.Song) error {
+	if _, ok := m.songs[id]; !ok {
+		return repository.ErrNotFound
+	}
+	song.ID = id
+	m.songs[id] = song
+	return nil
+}
+
+func (m *mockSongRepository) Delete(ctx context.Context, id int) error {
+	if _, ok := m.songs[id]; !ok {
+		return repository.ErrNotFound
+	}
+	delete(m.songs, id)
+	return nil
+}
+
+func setupTestRouter(repo repository.SongRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := NewSongController(repo, enricher.New("", 10, 5))
+	r := gin.New()
+	r.GET("/songs", controller.GetSongs)
+	r.GET("/songs/:id/text", controller.GetSongText)
+	r.GET("/songs/:id/status", controller.GetSongStatus)
+	r.DELETE("/songs/:id", controller.DeleteSong)
+	r.PUT("/songs/:id", controller.UpdateSong)
+	r.POST("/songs", controller.CreateSong)
+	return r
+}
+
+func TestGetSongs(t *testing.T) {
+	tests := []struct {
+		name       string
+		repo       *mockSongRepository
+		query      string
+		wantStatus int
+	}{
+		{"empty list", newMockSongRepository(), "?page=1&limit=10", http.StatusOK},
+		{"one song", newMockSongRepository(models.Song{ID: 1, Title: "Song", Artist: "Artist"}), "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupTestRouter(tt.repo)
+			req, _ := http.NewRequest(http.MethodGet, "/songs"+tt.query, nil)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d; got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestCreateSong(t *testing.T) {
+	r := setupTestRouter(newMockSongRepository())
+	jsonStr := []byte(`{"title":"Test Song", "artist":"Test Artist", "releaseDate":"2022-01-01", "text":"Test lyrics", "link":"test.com"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/songs", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, w.Code)
+	}
+
+	var song models.Song
+	if err := json.Unmarshal(w.Body.Bytes(), &song); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if song.Title != "Test Song" {
+		t.Errorf("expected song title 'Test Song'; got %q", song.Title)
+	}
+	if song.EnrichmentStatus != string(enricher.StatusEnriched) {
+		t.Errorf("expected song to be enriched already since all fields were provided; got status %q", song.EnrichmentStatus)
+	}
+}
+
+func TestCreateSongQueuesEnrichment(t *testing.T) {
+	r := setupTestRouter(newMockSongRepository())
+	jsonStr := []byte(`{"title":"Test Song", "artist":"Test Artist"}`)
+	req, _ := http.NewRequest(http.MethodPost, "/songs", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status %d; got %d", http.StatusCreated, w.Code)
+	}
+
+	var song models.Song
+	if err := json.Unmarshal(w.Body.Bytes(), &song); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if song.EnrichmentStatus != string(enricher.StatusPending) {
+		t.Errorf("expected song to be pending enrichment; got status %q", song.EnrichmentStatus)
+	}
+}
+
+func TestGetSongText(t *testing.T) {
+	repo := newMockSongRepository(models.Song{ID: 1, Title: "Song", Artist: "Artist", Text: "verse one\n\nverse two"})
+	r := setupTestRouter(repo)
+	req, _ := http.NewRequest(http.MethodGet, "/songs/1/text?page=1&limit=2", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d; got %d", http.StatusOK, w.Code)
+	}
+
+	var verses []string
+	if err := json.Unmarshal(w.Body.Bytes(), &verses); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(verses) != 2 {
+		t.Errorf("expected 2 verses; got %d", len(verses))
+	}
+}
+
+func TestGetSongTextNotFound(t *testing.T) {
+	r := setupTestRouter(newMockSongRepository())
+	req, _ := http.NewRequest(http.MethodGet, "/songs/1/text", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestUpdateSong(t *testing.T) {
+	repo := newMockSongRepository(models.Song{ID: 1, Title: "Song", Artist: "Artist"})
+	r := setupTestRouter(repo)
+	jsonStr := []byte(`{"title":"Updated Song", "artist":"Updated Artist", "releaseDate":"2022-02-01", "text":"Updated lyrics", "link":"updated.com"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/songs/1", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestUpdateSongNotFound(t *testing.T) {
+	r := setupTestRouter(newMockSongRepository())
+	jsonStr := []byte(`{"title":"Updated Song", "artist":"Updated Artist", "releaseDate":"2023-01-01", "text":"text", "link":"https://example.com"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/songs/1", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestUpdateSongRejectsPartialBody(t *testing.T) {
+	original := models.Song{ID: 1, Title: "Song", Artist: "Artist", ReleaseDate: "2021-01-01", Text: "lyrics", Link: "original.com"}
+	repo := newMockSongRepository(original)
+	r := setupTestRouter(repo)
+	jsonStr := []byte(`{"title":"Updated Song", "artist":"Updated Artist"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/songs/1", bytes.NewBuffer(jsonStr))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d; got %d", http.StatusBadRequest, w.Code)
+	}
+
+	stored, _ := repo.Get(context.Background(), 1)
+	if stored.ReleaseDate != original.ReleaseDate || stored.Text != original.Text || stored.Link != original.Link {
+		t.Errorf("partial update must not be applied; got %+v", stored)
+	}
+}
+
+func TestDeleteSong(t *testing.T) {
+	repo := newMockSongRepository(models.Song{ID: 1, Title: "Song", Artist: "Artist"})
+	r := setupTestRouter(repo)
+	req, _ := http.NewRequest(http.MethodDelete, "/songs/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status %d; got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestDeleteSongNotFound(t *testing.T) {
+	r := setupTestRouter(newMockSongRepository())
+	req, _ := http.NewRequest(http.MethodDelete, "/songs/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d; got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,60 @@
+// Package db инкапсулирует подключение к Postgres и прогон схемы миграций.
+package db
+
+import (
+	"embed"
+	"fmt"
+	"os"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/pressly/goose/v3"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Config описывает параметры подключения к Postgres.
+type Config struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Name     string
+}
+
+// ConfigFromEnv читает параметры подключения из переменных окружения
+// DB_HOST, DB_PORT, DB_USER, DB_PASSWORD, DB_NAME.
+func ConfigFromEnv() Config {
+	return Config{
+		Host:     os.Getenv("DB_HOST"),
+		Port:     os.Getenv("DB_PORT"),
+		User:     os.Getenv("DB_USER"),
+		Password: os.Getenv("DB_PASSWORD"),
+		Name:     os.Getenv("DB_NAME"),
+	}
+}
+
+// EnsureDB открывает соединение с Postgres и доводит схему до последней
+// версии через встроенные goose-миграции из db/migrations.
+func EnsureDB(cfg Config) (*sqlx.DB, error) {
+	connStr := fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name,
+	)
+
+	conn, err := sqlx.Connect("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to postgres: %w", err)
+	}
+
+	goose.SetBaseFS(migrations)
+	if err := goose.SetDialect("postgres"); err != nil {
+		return nil, fmt.Errorf("setting goose dialect: %w", err)
+	}
+	if err := goose.Up(conn.DB, "migrations"); err != nil {
+		return nil, fmt.Errorf("running migrations: %w", err)
+	}
+
+	return conn, nil
+}
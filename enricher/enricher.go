@@ -0,0 +1,203 @@
+package enricher
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Status представляет состояние обогащения песни метаданными.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusEnriched Status = "enriched"
+	StatusFailed   Status = "failed"
+)
+
+// maxRetries задаёт число попыток обращения к Music Info API на одну работу.
+const maxRetries = 3
+
+var (
+	enrichSuccessTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "enrichment_success_total",
+		Help: "Total number of songs successfully enriched via the Music Info API.",
+	})
+	enrichFailureTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "enrichment_failure_total",
+		Help: "Total number of song enrichment jobs that failed after all retries.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(enrichSuccessTotal, enrichFailureTotal)
+}
+
+// Job описывает единицу работы по обогащению строки в таблице songs.
+type Job struct {
+	SongID int
+	Artist string
+	Title  string
+}
+
+// songInfo отражает ответ Music Info API.
+type songInfo struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Enricher управляет пулом воркеров, которые дополняют песни данными из
+// внешнего Music Info API и защищают его от шторма запросов через circuit
+// breaker на последовательных отказах.
+type Enricher struct {
+	apiURL      string
+	db          *sqlx.DB
+	client      *http.Client
+	jobs        chan Job
+	maxFailures int
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	circuitOpenUntil    time.Time
+}
+
+// New создаёт Enricher с буферизованной очередью заданий размера queueSize.
+// maxFailures — число подряд заданий, у которых исчерпаны все попытки
+// обращения к API, после которого circuit breaker размыкается на одну минуту. Размер пула воркеров
+// передаётся отдельно в Start.
+func New(apiURL string, queueSize, maxFailures int) *Enricher {
+	return &Enricher{
+		apiURL:      apiURL,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		jobs:        make(chan Job, queueSize),
+		maxFailures: maxFailures,
+	}
+}
+
+// Start запускает пул воркеров, читающих задания из очереди.
+func (e *Enricher) Start(db *sqlx.DB, workers int) {
+	e.db = db
+	for i := 0; i < workers; i++ {
+		go e.runWorker()
+	}
+}
+
+// Enqueue ставит песню в очередь на обогащение. Пока в буфере очереди есть
+// место, вызов не блокируется; при заполненном буфере он ждёт, пока воркер
+// не заберёт задание.
+func (e *Enricher) Enqueue(job Job) {
+	e.jobs <- job
+}
+
+func (e *Enricher) runWorker() {
+	for job := range e.jobs {
+		e.process(job)
+	}
+}
+
+func (e *Enricher) process(job Job) {
+	if e.circuitOpen() {
+		slog.Warn("enricher: circuit open, skipping song", "song_id", job.SongID)
+		e.markFailed(job.SongID)
+		return
+	}
+
+	info, err := e.fetchWithRetry(job)
+	if err != nil {
+		slog.Error("enricher: giving up on song", "song_id", job.SongID, "error", err)
+		e.recordFailure()
+		e.markFailed(job.SongID)
+		return
+	}
+
+	e.recordSuccess()
+	if _, err := e.db.Exec(
+		"UPDATE songs SET release_date = COALESCE(NULLIF(release_date, ''), $1), text = COALESCE(NULLIF(text, ''), $2), link = COALESCE(NULLIF(link, ''), $3), enrichment_status = $4 WHERE id = $5",
+		info.ReleaseDate, info.Text, info.Link, StatusEnriched, job.SongID,
+	); err != nil {
+		slog.Error("enricher: failed to persist enrichment", "song_id", job.SongID, "error", err)
+	}
+}
+
+func (e *Enricher) fetchWithRetry(job Job) (*songInfo, error) {
+	var lastErr error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(math.Pow(2, float64(attempt))) * time.Second
+			time.Sleep(backoff)
+		}
+
+		info, err := e.fetch(job)
+		if err == nil {
+			return info, nil
+		}
+		lastErr = err
+		slog.Warn("enricher: fetch attempt failed", "attempt", attempt+1, "max_attempts", maxRetries, "song_id", job.SongID, "error", err)
+	}
+	return nil, lastErr
+}
+
+func (e *Enricher) fetch(job Job) (*songInfo, error) {
+	query := url.Values{}
+	query.Set("group", job.Artist)
+	query.Set("song", job.Title)
+	reqURL := fmt.Sprintf("%s/info?%s", e.apiURL, query.Encode())
+
+	resp, err := e.client.Get(reqURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("music info api returned status %d", resp.StatusCode)
+	}
+
+	var info songInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decoding music info response: %w", err)
+	}
+	return &info, nil
+}
+
+func (e *Enricher) markFailed(songID int) {
+	if _, err := e.db.Exec("UPDATE songs SET enrichment_status = $1 WHERE id = $2", StatusFailed, songID); err != nil {
+		slog.Error("enricher: failed to mark song as failed", "song_id", songID, "error", err)
+	}
+}
+
+// circuitOpen сообщает, разомкнут ли circuit breaker после серии отказов.
+func (e *Enricher) circuitOpen() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return time.Now().Before(e.circuitOpenUntil)
+}
+
+func (e *Enricher) recordFailure() {
+	enrichFailureTotal.Inc()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.consecutiveFailures++
+	if e.consecutiveFailures >= e.maxFailures {
+		e.circuitOpenUntil = time.Now().Add(time.Minute)
+		slog.Warn("enricher: circuit breaker tripped", "consecutive_failures", e.consecutiveFailures)
+	}
+}
+
+func (e *Enricher) recordSuccess() {
+	enrichSuccessTotal.Inc()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.consecutiveFailures = 0
+}
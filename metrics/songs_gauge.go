@@ -0,0 +1,48 @@
+// Package metrics содержит Prometheus-метрики, не привязанные к отдельному
+// HTTP-запросу.
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var songsTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "songs_total",
+	Help: "Current number of songs stored in the database.",
+})
+
+func init() {
+	prometheus.MustRegister(songsTotal)
+}
+
+// WatchSongsTotal периодически пересчитывает songs_total, пока не закроется
+// ctx.
+func WatchSongsTotal(ctx context.Context, db *sqlx.DB, interval time.Duration) {
+	refreshSongsTotal(ctx, db)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshSongsTotal(ctx, db)
+		}
+	}
+}
+
+func refreshSongsTotal(ctx context.Context, db *sqlx.DB) {
+	var count int
+	if err := db.GetContext(ctx, &count, "SELECT COUNT(*) FROM songs"); err != nil {
+		slog.Error("failed to refresh songs_total metric", "error", err)
+		return
+	}
+	songsTotal.Set(float64(count))
+}
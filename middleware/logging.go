@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logging логирует каждый запрос в JSON через slog с полями request_id,
+// method, path, status, latency_ms и song_id (если в маршруте есть :id).
+func Logging(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		logger.Info("http_request",
+			"request_id", RequestIDFromContext(c),
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"status", c.Writer.Status(),
+			"latency_ms", time.Since(start).Milliseconds(),
+			"song_id", c.Param("id"),
+		)
+	}
+}
@@ -0,0 +1,34 @@
+// Package middleware содержит сквозные Gin-обработчики: request ID,
+// структурное логирование и метрики Prometheus.
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader — заголовок, через который клиент может передать свой
+// request ID, а сервис — вернуть тот, что был сгенерирован или подтверждён.
+const RequestIDHeader = "X-Request-ID"
+
+const requestIDContextKey = "request_id"
+
+// RequestID проставляет request ID на контекст запроса: берёт его из
+// заголовка X-Request-ID, если он есть, иначе генерирует UUIDv4.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.NewString()
+		}
+		c.Set(requestIDContextKey, id)
+		c.Writer.Header().Set(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// RequestIDFromContext возвращает request ID текущего запроса, либо пустую
+// строку, если middleware RequestID не был подключён.
+func RequestIDFromContext(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
@@ -0,0 +1,100 @@
+// Package models содержит типы данных, используемые на всех слоях сервиса.
+package models
+
+// Song представляет модель песни
+// @Description Структура для представления информации о песне
+type Song struct {
+	// ID песни
+	// @example 1
+	ID int `json:"id" db:"id"`
+
+	// Название песни
+	// @example "Song Title"
+	Title string `json:"title" db:"title" binding:"required"`
+
+	// Исполнитель песни
+	// @example "Artist Name"
+	Artist string `json:"artist" db:"artist" binding:"required"`
+
+	// Дата выпуска песни. Если клиент её не передал, будет заполнена
+	// воркером обогащения после обращения к Music Info API.
+	// @example "2023-11-26"
+	ReleaseDate string `json:"releaseDate" db:"release_date"`
+
+	// Текст песни. Если клиент его не передал, будет заполнен воркером
+	// обогащения после обращения к Music Info API.
+	// @example "This is the text of the song."
+	Text string `json:"text" db:"text"`
+
+	// Ссылка на песню. Если клиент её не передал, будет заполнена воркером
+	// обогащения после обращения к Music Info API.
+	// @example "https://example.com/song-link"
+	Link string `json:"link" db:"link"`
+
+	// Состояние фонового обогащения метаданными: pending, enriched или failed
+	// @example "pending"
+	EnrichmentStatus string `json:"enrichmentStatus" db:"enrichment_status"`
+}
+
+// SongUpdateRequest представляет тело PUT /songs/{id}. В отличие от Song,
+// все поля обязательны: PUT полностью заменяет запись, и без этого частичное
+// тело молча обнулило бы releaseDate/text/link, заполненные обогащением.
+// @Description Структура для обновления песни; все поля обязательны
+type SongUpdateRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Artist      string `json:"artist" binding:"required"`
+	ReleaseDate string `json:"releaseDate" binding:"required"`
+	Text        string `json:"text" binding:"required"`
+	Link        string `json:"link" binding:"required"`
+}
+
+// Pagination представляет модель пагинации
+type Pagination struct {
+	Limit int `form:"limit"`
+	Page  int `form:"page"`
+}
+
+// SongFilter описывает параметры фильтрации, полнотекстового поиска и
+// сортировки, принимаемые GET /songs.
+type SongFilter struct {
+	Title           string `form:"title"`
+	Artist          string `form:"artist"`
+	Group           string `form:"group"`
+	ReleaseDateFrom string `form:"releaseDateFrom"`
+	ReleaseDateTo   string `form:"releaseDateTo"`
+	Q               string `form:"q"`
+	// Sort, например "releaseDate:desc,title:asc"
+	Sort string `form:"sort"`
+}
+
+// SongListResponse представляет постраничный ответ GET /songs
+// @Description Постраничный список песен с метаданными пагинации
+type SongListResponse struct {
+	Data  []Song `json:"data"`
+	Total int    `json:"total"`
+	Page  int    `json:"page"`
+	Limit int    `json:"limit"`
+}
+
+// SuccessResponse представляет структуру для успешных ответов API
+// @Description Структура для успешного ответа в API
+type SuccessResponse struct {
+	Message string `json:"message"`
+}
+
+// ErrorResponse представляет структуру для ошибок API
+// @Description Структура для представления ошибок в API
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// EnrichmentStatusResponse представляет ответ эндпоинта статуса обогащения
+// @Description Структура для представления статуса фонового обогащения песни
+type EnrichmentStatusResponse struct {
+	Status string `json:"status"`
+}
+
+// Columns перечисляет колонки songs, которые отражены в модели Song;
+// group_name вынесен в отдельную таблицу groups (см.
+// db/migrations/0002_add_group_table.sql).
+const Columns = "id, title, artist, release_date, text, link, enrichment_status"
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NickLand74/Mobile-library-api/models"
+)
+
+// songListColumns qualifies every column with the songs table so that the
+// optional LEFT JOIN against groups in joinClause can't introduce ambiguity.
+const songListColumns = "songs.id, songs.title, songs.artist, songs.release_date, songs.text, songs.link, songs.enrichment_status"
+
+// sortableColumns maps the public sort keys accepted by GET /songs to the
+// underlying songs columns.
+var sortableColumns = map[string]string{
+	"title":       "songs.title",
+	"artist":      "songs.artist",
+	"releaseDate": "songs.release_date",
+}
+
+// joinClause adds a LEFT JOIN against groups only when filtering by group
+// name, since that's the only case that needs it.
+func joinClause(filter models.SongFilter) string {
+	if filter.Group == "" {
+		return ""
+	}
+	return " LEFT JOIN groups ON groups.id = songs.group_id"
+}
+
+// buildSongFilter turns a models.SongFilter into a parameterized WHERE
+// clause (empty string if there's nothing to filter on) and its arguments,
+// so callers never interpolate user input into SQL.
+func buildSongFilter(filter models.SongFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.Title != "" {
+		addCondition("songs.title ILIKE $%d", "%"+filter.Title+"%")
+	}
+	if filter.Artist != "" {
+		addCondition("songs.artist ILIKE $%d", "%"+filter.Artist+"%")
+	}
+	if filter.Group != "" {
+		addCondition("groups.name = $%d", filter.Group)
+	}
+	if filter.ReleaseDateFrom != "" {
+		addCondition("songs.release_date >= $%d", filter.ReleaseDateFrom)
+	}
+	if filter.ReleaseDateTo != "" {
+		addCondition("songs.release_date <= $%d", filter.ReleaseDateTo)
+	}
+	if filter.Q != "" {
+		addCondition(
+			"to_tsvector('simple', songs.title || ' ' || songs.artist || ' ' || songs.text) @@ plainto_tsquery('simple', $%d)",
+			filter.Q,
+		)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderByClause translates a "field:direction,field:direction" sort string
+// (e.g. "releaseDate:desc,title:asc") into a safe ORDER BY clause, falling
+// back to "songs.id ASC" when sort is empty or every field is unrecognized.
+func orderByClause(sort string) string {
+	if sort == "" {
+		return "songs.id ASC"
+	}
+
+	var parts []string
+	for _, field := range strings.Split(sort, ",") {
+		name, direction, _ := strings.Cut(field, ":")
+		column, ok := sortableColumns[name]
+		if !ok {
+			continue
+		}
+		if direction != "desc" {
+			direction = "asc"
+		}
+		parts = append(parts, column+" "+strings.ToUpper(direction))
+	}
+
+	if len(parts) == 0 {
+		return "songs.id ASC"
+	}
+	return strings.Join(parts, ", ")
+}
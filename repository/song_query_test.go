@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NickLand74/Mobile-library-api/models"
+)
+
+func TestJoinClause(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.SongFilter
+		want   string
+	}{
+		{name: "no group filter", filter: models.SongFilter{}, want: ""},
+		{
+			name:   "group filter adds join",
+			filter: models.SongFilter{Group: "Metallica"},
+			want:   " LEFT JOIN groups ON groups.id = songs.group_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinClause(tt.filter); got != tt.want {
+				t.Errorf("joinClause(%+v) = %q; want %q", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildSongFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   models.SongFilter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "empty filter",
+			filter:   models.SongFilter{},
+			wantSQL:  "",
+			wantArgs: nil,
+		},
+		{
+			name:     "title only",
+			filter:   models.SongFilter{Title: "Love"},
+			wantSQL:  " WHERE songs.title ILIKE $1",
+			wantArgs: []interface{}{"%Love%"},
+		},
+		{
+			name:     "title and artist combine with AND",
+			filter:   models.SongFilter{Title: "Love", Artist: "Beatles"},
+			wantSQL:  " WHERE songs.title ILIKE $1 AND songs.artist ILIKE $2",
+			wantArgs: []interface{}{"%Love%", "%Beatles%"},
+		},
+		{
+			name:     "group filter",
+			filter:   models.SongFilter{Group: "Metallica"},
+			wantSQL:  " WHERE groups.name = $1",
+			wantArgs: []interface{}{"Metallica"},
+		},
+		{
+			name:     "release date range",
+			filter:   models.SongFilter{ReleaseDateFrom: "2000-01-01", ReleaseDateTo: "2010-01-01"},
+			wantSQL:  " WHERE songs.release_date >= $1 AND songs.release_date <= $2",
+			wantArgs: []interface{}{"2000-01-01", "2010-01-01"},
+		},
+		{
+			name:     "full-text search",
+			filter:   models.SongFilter{Q: "rock"},
+			wantSQL:  " WHERE to_tsvector('simple', songs.title || ' ' || songs.artist || ' ' || songs.text) @@ plainto_tsquery('simple', $1)",
+			wantArgs: []interface{}{"rock"},
+		},
+		{
+			name:     "all filters placeholder indices increment in order",
+			filter:   models.SongFilter{Title: "a", Artist: "b", Group: "c", ReleaseDateFrom: "d", ReleaseDateTo: "e", Q: "f"},
+			wantSQL:  " WHERE songs.title ILIKE $1 AND songs.artist ILIKE $2 AND groups.name = $3 AND songs.release_date >= $4 AND songs.release_date <= $5 AND to_tsvector('simple', songs.title || ' ' || songs.artist || ' ' || songs.text) @@ plainto_tsquery('simple', $6)",
+			wantArgs: []interface{}{"%a%", "%b%", "c", "d", "e", "f"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs := buildSongFilter(tt.filter)
+			if gotSQL != tt.wantSQL {
+				t.Errorf("buildSongFilter(%+v) sql = %q; want %q", tt.filter, gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("buildSongFilter(%+v) args = %#v; want %#v", tt.filter, gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestOrderByClause(t *testing.T) {
+	tests := []struct {
+		name string
+		sort string
+		want string
+	}{
+		{name: "empty sort defaults to id asc", sort: "", want: "songs.id ASC"},
+		{name: "single field defaults to asc", sort: "title", want: "songs.title ASC"},
+		{name: "explicit direction", sort: "releaseDate:desc", want: "songs.release_date DESC"},
+		{
+			name: "multiple fields",
+			sort: "releaseDate:desc,title:asc",
+			want: "songs.release_date DESC, songs.title ASC",
+		},
+		{name: "unrecognized field is dropped", sort: "bogus:desc", want: "songs.id ASC"},
+		{
+			name: "unrecognized field among valid ones is dropped",
+			sort: "bogus:desc,artist:asc",
+			want: "songs.artist ASC",
+		},
+		{name: "unrecognized direction falls back to asc", sort: "title:sideways", want: "songs.title ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderByClause(tt.sort); got != tt.want {
+				t.Errorf("orderByClause(%q) = %q; want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
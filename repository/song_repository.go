@@ -0,0 +1,144 @@
+// Package repository изолирует доступ к хранилищу песен от HTTP-слоя.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/NickLand74/Mobile-library-api/models"
+)
+
+// ErrNotFound возвращается, когда запрошенная песня отсутствует в хранилище.
+var ErrNotFound = errors.New("song not found")
+
+// SongRepository описывает операции над хранилищем песен, которые нужны
+// контроллеру. Интерфейс существует отдельно от реализации, чтобы
+// контроллер можно было тестировать с мок-реализацией без реальной БД.
+type SongRepository interface {
+	List(ctx context.Context, filter models.SongFilter, pagination models.Pagination) ([]models.Song, int, error)
+	Get(ctx context.Context, id int) (models.Song, error)
+	GetText(ctx context.Context, id int) (string, error)
+	GetStatus(ctx context.Context, id int) (string, error)
+	Create(ctx context.Context, song models.Song) (models.Song, error)
+	Update(ctx context.Context, id int, song models.Song) error
+	Delete(ctx context.Context, id int) error
+}
+
+type postgresSongRepository struct {
+	db *sqlx.DB
+}
+
+// NewSongRepository создаёт SongRepository поверх Postgres.
+func NewSongRepository(db *sqlx.DB) SongRepository {
+	return &postgresSongRepository{db: db}
+}
+
+func (r *postgresSongRepository) List(ctx context.Context, filter models.SongFilter, pagination models.Pagination) ([]models.Song, int, error) {
+	limit := pagination.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := pagination.Page
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	where, args := buildSongFilter(filter)
+
+	var total int
+	countQuery := "SELECT COUNT(*) FROM songs" + joinClause(filter) + where
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, 0, err
+	}
+
+	listArgs := append(append([]interface{}{}, args...), limit, offset)
+	listQuery := "SELECT " + songListColumns + " FROM songs" + joinClause(filter) + where +
+		" ORDER BY " + orderByClause(filter.Sort) +
+		fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+
+	var songs []models.Song
+	if err := r.db.SelectContext(ctx, &songs, listQuery, listArgs...); err != nil {
+		return nil, 0, err
+	}
+	return songs, total, nil
+}
+
+func (r *postgresSongRepository) Get(ctx context.Context, id int) (models.Song, error) {
+	var song models.Song
+	query := "SELECT " + models.Columns + " FROM songs WHERE id = $1"
+	if err := r.db.GetContext(ctx, &song, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Song{}, ErrNotFound
+		}
+		return models.Song{}, err
+	}
+	return song, nil
+}
+
+func (r *postgresSongRepository) GetText(ctx context.Context, id int) (string, error) {
+	var text string
+	query := "SELECT text FROM songs WHERE id = $1"
+	if err := r.db.GetContext(ctx, &text, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return text, nil
+}
+
+func (r *postgresSongRepository) GetStatus(ctx context.Context, id int) (string, error) {
+	var status string
+	query := "SELECT enrichment_status FROM songs WHERE id = $1"
+	if err := r.db.GetContext(ctx, &status, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	return status, nil
+}
+
+func (r *postgresSongRepository) Create(ctx context.Context, song models.Song) (models.Song, error) {
+	query := "INSERT INTO songs (title, artist, release_date, text, link, enrichment_status) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+	err := r.db.QueryRowContext(ctx, query,
+		song.Title, song.Artist, song.ReleaseDate, song.Text, song.Link, song.EnrichmentStatus,
+	).Scan(&song.ID)
+	if err != nil {
+		return models.Song{}, err
+	}
+	return song, nil
+}
+
+func (r *postgresSongRepository) Update(ctx context.Context, id int, song models.Song) error {
+	query := "UPDATE songs SET title = $1, artist = $2, release_date = $3, text = $4, link = $5 WHERE id = $6"
+	result, err := r.db.ExecContext(ctx, query, song.Title, song.Artist, song.ReleaseDate, song.Text, song.Link, id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+func (r *postgresSongRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM songs WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	return checkRowsAffected(result)
+}
+
+func checkRowsAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
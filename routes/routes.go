@@ -0,0 +1,25 @@
+// Package routes связывает HTTP-маршруты Gin с контроллерами.
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/NickLand74/Mobile-library-api/controllers"
+)
+
+// Register регистрирует маршруты песен на переданном Gin-движке.
+func Register(r *gin.Engine, songController *controllers.SongController) {
+	r.GET("/songs", songController.GetSongs)
+	r.GET("/songs/:id/text", songController.GetSongText)
+	r.GET("/songs/:id/status", songController.GetSongStatus)
+	r.DELETE("/songs/:id", songController.DeleteSong)
+	r.PUT("/songs/:id", songController.UpdateSong)
+	r.POST("/songs", songController.CreateSong)
+}
+
+// RegisterHealth регистрирует liveness- и readiness-эндпоинты, которые
+// Docker/Kubernetes используют как healthcheck.
+func RegisterHealth(r *gin.Engine, healthController *controllers.HealthController) {
+	r.GET("/healthz", healthController.Live)
+	r.GET("/readyz", healthController.Ready)
+}